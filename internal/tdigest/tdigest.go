@@ -0,0 +1,121 @@
+// Package tdigest implements a t-digest, a data structure for estimating
+// quantiles of a stream of values in O(log n) per insert and bounded memory,
+// without keeping every observation around for a sort-and-index.
+package tdigest
+
+import (
+	"math"
+	"sort"
+)
+
+// Centroid is a weighted mean: Count observations have averaged out to Mean.
+type Centroid struct {
+	Mean  float64
+	Count float64
+}
+
+// TDigest is a t-digest with the given compression parameter; higher values
+// trade more memory (more centroids) for more accurate quantiles. 100 is a
+// common default.
+type TDigest struct {
+	Compression float64
+	centroids   []Centroid
+	count       float64
+}
+
+// New returns an empty TDigest with the given compression.
+func New(compression float64) *TDigest {
+	return &TDigest{Compression: compression}
+}
+
+// Add records a single observation.
+func (t *TDigest) Add(x float64) {
+	t.AddWeighted(x, 1)
+}
+
+// AddWeighted records an observation that represents weight identical values.
+func (t *TDigest) AddWeighted(x float64, weight float64) {
+	if len(t.centroids) == 0 {
+		t.centroids = append(t.centroids, Centroid{Mean: x, Count: weight})
+		t.count = weight
+		return
+	}
+
+	idx := sort.Search(len(t.centroids), func(i int) bool { return t.centroids[i].Mean >= x })
+	candidate := nearest(t.centroids, idx, x)
+
+	var before float64
+	for i := 0; i < candidate; i++ {
+		before += t.centroids[i].Count
+	}
+
+	total := t.count + weight
+	q1 := before / total
+	q2 := (before + t.centroids[candidate].Count + weight) / total
+
+	if scale(q2, t.Compression)-scale(q1, t.Compression) <= 1 {
+		c := &t.centroids[candidate]
+		c.Mean = (c.Mean*c.Count + x*weight) / (c.Count + weight)
+		c.Count += weight
+	} else {
+		t.centroids = append(t.centroids, Centroid{})
+		copy(t.centroids[idx+1:], t.centroids[idx:])
+		t.centroids[idx] = Centroid{Mean: x, Count: weight}
+	}
+	t.count = total
+}
+
+// Quantile returns an estimate of the value at rank q, q in [0, 1]. It
+// returns NaN if no observations have been added.
+func (t *TDigest) Quantile(q float64) float64 {
+	if len(t.centroids) == 0 {
+		return math.NaN()
+	}
+	if len(t.centroids) == 1 {
+		return t.centroids[0].Mean
+	}
+
+	target := q * t.count
+	var cumulative float64
+	for i, c := range t.centroids {
+		if cumulative+c.Count >= target {
+			if i == 0 {
+				return c.Mean
+			}
+			prev := t.centroids[i-1]
+			ratio := (target - cumulative) / c.Count
+			return prev.Mean + ratio*(c.Mean-prev.Mean)
+		}
+		cumulative += c.Count
+	}
+
+	return t.centroids[len(t.centroids)-1].Mean
+}
+
+// Count returns the total weight of observations added so far.
+func (t *TDigest) Count() float64 {
+	return t.count
+}
+
+// nearest picks whichever of the centroids adjacent to the sorted insertion
+// point idx has a mean closest to x.
+func nearest(centroids []Centroid, idx int, x float64) int {
+	switch {
+	case idx == 0:
+		return 0
+	case idx == len(centroids):
+		return idx - 1
+	case math.Abs(centroids[idx-1].Mean-x) <= math.Abs(centroids[idx].Mean-x):
+		return idx - 1
+	default:
+		return idx
+	}
+}
+
+// scale is the k1 scale function: k(q, δ) = δ/(2π) · arcsin(2q-1). A value
+// may merge into a centroid only if the merged centroid spans at most 1 in
+// scale-function value, which keeps centroid size proportional to √(q(1-q)) — small near
+// the tails, where quantile accuracy matters most, and larger in the middle.
+func scale(q, compression float64) float64 {
+	return compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
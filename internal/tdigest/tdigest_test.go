@@ -0,0 +1,55 @@
+package tdigest
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// TestTDigestQuantiles checks the digest's estimates against the exact
+// order statistics of a known distribution, computed by sorting the same
+// values. A t-digest is an approximation, so this allows a tolerance rather
+// than asserting exact equality — but a broken scale function or insertion
+// would blow well past it.
+func TestTDigestQuantiles(t *testing.T) {
+	const n = 1000
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = float64(i)
+	}
+
+	d := New(100)
+	// Insert out of order (37 is coprime with n, so this visits every index
+	// exactly once) so the digest can't coast on already-sorted input.
+	for i := 0; i < n; i++ {
+		d.Add(values[(i*37)%n])
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	exact := func(q float64) float64 {
+		return sorted[int(q*float64(len(sorted)-1))]
+	}
+
+	const tolerance = 20.0
+	for _, q := range []float64{0.1, 0.5, 0.9, 0.99} {
+		got := d.Quantile(q)
+		want := exact(q)
+		if diff := math.Abs(got - want); diff > tolerance {
+			t.Errorf("Quantile(%v) = %v, want within %v of %v", q, got, tolerance, want)
+		}
+	}
+
+	if d.Count() != n {
+		t.Errorf("Count() = %v, want %v", d.Count(), n)
+	}
+}
+
+// TestTDigestEmptyQuantileIsNaN guards the documented zero-value behavior:
+// an empty digest has no centroids to interpolate between.
+func TestTDigestEmptyQuantileIsNaN(t *testing.T) {
+	d := New(100)
+	if q := d.Quantile(0.5); !math.IsNaN(q) {
+		t.Errorf("Quantile(0.5) on empty digest = %v, want NaN", q)
+	}
+}
@@ -1,23 +1,51 @@
 package main
 
 import (
+	"context"
 	"encoding/csv"
+	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"strconv"
 	"strings"
-	"sort"
+	"time"
 	"math"
+	"math/rand"
 
+	"github.com/Cookiefied/ProcessScheduler/internal/tdigest"
+	"github.com/Cookiefied/ProcessScheduler/scheduler"
 	"github.com/olekukonko/tablewriter"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := runGenerate(os.Stdout, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// CLI flags
+	format := flag.String("format", "text", "output format: text, json, csv")
+	benchmark := flag.Bool("benchmark", false, "run every scheduler over the same input and print a comparison matrix")
+	quantum := flag.Float64("quantum", 1, "time quantum used for the round-robin scheduler in -benchmark, -live and -serve modes")
+	serve := flag.String("serve", "", "if set (e.g. :8080), serve a live-updating Gantt chart over HTTP via SSE instead of printing output")
+	live := flag.Bool("live", false, "replay the schedule as live ticking events on the terminal (paced by -tick-duration) before printing the final table")
+	tickDuration := flag.Duration("tick-duration", 150*time.Millisecond, "pacing between ticks in -live mode")
+	algorithm := flag.String("algorithm", "rr", "algorithm to visualize in -live/-serve mode: fcfs, sjf, priority, rr, mlfq")
+	flag.Parse()
+
+	outFormat, err := ParseOutputFormat(*format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// CLI args
-	f, closeFile, err := openProcessingFile(os.Args...)
+	f, closeFile, err := openProcessingFile(flag.Args())
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -29,347 +57,375 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if *serve != "" {
+		algo, err := algorithmByName(*quantum, *algorithm)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := runServe(*serve, algo, processes); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *live {
+		algo, err := algorithmByName(*quantum, *algorithm)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := runLive(os.Stdout, algo, processes, *tickDuration, outFormat); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *benchmark {
+		runBenchmark(os.Stdout, processes, *quantum)
+		return
+	}
+
 	// First-come, first-serve scheduling
-	FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
+	Render(os.Stdout, scheduler.FCFSSchedule("First-come, first-serve", processes), outFormat)
 
 	// Shortest Job First Preemptive Scheduling
-	SJFSchedule(os.Stdout, "Shortest-job-first", processes)
-	
+	Render(os.Stdout, scheduler.SJFSchedule("Shortest-job-first", processes), outFormat)
+
 	// Shortest Job First Preemptive, Priority Scheduling
-	SJFPrioritySchedule(os.Stdout, "Priority", processes)
-	
+	Render(os.Stdout, scheduler.SJFPrioritySchedule("Priority", processes), outFormat)
+
 	// Round-Robin Preemptive Scheduling
 	// By default, we use a time quantum of 1 second. This can be changed below by changing 1 to a different value
-	RRSchedule(os.Stdout, "Round-robin", processes, 1)
+	Render(os.Stdout, scheduler.RRSchedule("Round-robin", processes, 1), outFormat)
+
+	// Multi-level Feedback Queue Scheduling
+	// Three queues with increasing quanta, boosted back to the top queue every 16 ticks
+	Render(os.Stdout, scheduler.MLFQSchedule("Multi-level feedback queue", processes, []int64{2, 4, 8}, 16), outFormat)
 }
 
-func openProcessingFile(args ...string) (*os.File, func(), error) {
-	if len(args) != 2 {
-		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
-	}
-	// Read in CSV process CSV file
-	f, err := os.Open(args[1])
-	if err != nil {
-		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
-	}
-	closeFn := func() {
-		if err := f.Close(); err != nil {
-			log.Fatalf("%v: error closing scheduling file", err)
-		}
+// allAlgorithms returns every scheduling algorithm this program knows
+// about, wired up with the given round-robin quantum, in the order
+// -benchmark presents them and -serve's -algorithm flag can select from.
+func allAlgorithms(quantum float64) []scheduler.Algorithm {
+	return []scheduler.Algorithm{
+		{Name: "First-come, first-serve", Run: func(p []scheduler.Process) scheduler.SchedulerResult {
+			return scheduler.FCFSSchedule("First-come, first-serve", p)
+		}},
+		{Name: "Shortest-job-first", Run: func(p []scheduler.Process) scheduler.SchedulerResult {
+			return scheduler.SJFSchedule("Shortest-job-first", p)
+		}},
+		{Name: "Priority", Run: func(p []scheduler.Process) scheduler.SchedulerResult {
+			return scheduler.SJFPrioritySchedule("Priority", p)
+		}},
+		{Name: "Round-robin", Run: func(p []scheduler.Process) scheduler.SchedulerResult {
+			return scheduler.RRSchedule("Round-robin", p, quantum)
+		}},
+		{Name: "Multi-level feedback queue", Run: func(p []scheduler.Process) scheduler.SchedulerResult {
+			return scheduler.MLFQSchedule("Multi-level feedback queue", p, []int64{2, 4, 8}, 16)
+		}},
 	}
-
-	return f, closeFn, nil
 }
 
-type (
-	Process struct {
-		ProcessID     int64
-		ArrivalTime   int64
-		BurstDuration int64
-		Priority      int64
+// algorithmByName resolves the short names -serve's -algorithm flag accepts
+// (fcfs, sjf, priority, rr, mlfq) to one of allAlgorithms.
+func algorithmByName(quantum float64, name string) (scheduler.Algorithm, error) {
+	aliases := map[string]string{
+		"fcfs":     "First-come, first-serve",
+		"sjf":      "Shortest-job-first",
+		"priority": "Priority",
+		"rr":       "Round-robin",
+		"mlfq":     "Multi-level feedback queue",
 	}
-	TimeSlice struct {
-		PID   int64
-		Start int64
-		Stop  int64
+	target, ok := aliases[strings.ToLower(name)]
+	if !ok {
+		return scheduler.Algorithm{}, fmt.Errorf("%w: unknown algorithm %q", ErrInvalidArgs, name)
 	}
-)
-
-//region Schedulers
-
-// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
-// • an output writer
-// • a title for the chart
-// • a slice of processes
-func FCFSSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
+	for _, a := range allAlgorithms(quantum) {
+		if a.Name == target {
+			return a, nil
 		}
-		totalWait += float64(waitingTime)
+	}
+	return scheduler.Algorithm{}, fmt.Errorf("%w: unknown algorithm %q", ErrInvalidArgs, name)
+}
 
-		start := waitingTime + processes[i].ArrivalTime
+// runLive is the terminal counterpart to runServe: it drives algo over
+// processes through a scheduler.Engine and prints each Event as it's
+// replayed, paced by tickDuration, so a user watching a terminal sees the
+// schedule happen the same way a browser watching -serve's SSE stream does.
+// It then prints the finished table with Render, exactly as the non-live
+// path would.
+func runLive(w io.Writer, algo scheduler.Algorithm, processes []scheduler.Process, tickDuration time.Duration, format OutputFormat) error {
+	_, _ = fmt.Fprintf(w, "Running %q live...\n", algo.Name)
+
+	engine := &scheduler.Engine{
+		TickDuration: tickDuration,
+		// Report queue depth every 4 ticks so a long idle stretch between
+		// events still shows the run is progressing, not stalled.
+		HeartbeatEvery: 4,
+		Heartbeat: func(tick int64, waiting []int64) {
+			_, _ = fmt.Fprintf(w, "  -- t=%d waiting: %v --\n", tick, waiting)
+		},
+	}
+	events, result := engine.Run(context.Background(), algo, clonedProcesses(processes))
 
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
+	for ev := range events {
+		_, _ = fmt.Fprintf(w, "[t=%d] %s P%d\n", ev.Tick, ev.Kind, ev.PID)
+	}
 
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
+	return Render(w, result, format)
+}
 
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
+// runBenchmark runs every scheduler over a copy of the same input and prints
+// a comparison matrix of average metrics plus p50/p90/p99 waiting time,
+// using a t-digest so this stays cheap for large synthetic workloads.
+func runBenchmark(w io.Writer, processes []scheduler.Process, quantum float64) {
+	rows := make([][]string, 0)
+	for _, a := range allAlgorithms(quantum) {
+		result := a.Run(clonedProcesses(processes))
+
+		waitDigest := tdigest.New(100)
+		responseDigest := tdigest.New(100)
+		for _, row := range result.Rows {
+			waitDigest.Add(float64(row.Wait))
+			responseDigest.Add(float64(row.Response()))
 		}
-		serviceTime += processes[i].BurstDuration
 
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
+		rows = append(rows, []string{
+			a.Name,
+			fmt.Sprintf("%.2f", result.AveWait),
+			fmt.Sprintf("%.2f", result.AveTurnaround),
+			fmt.Sprintf("%.2f", result.AveThroughput),
+			fmt.Sprintf("%.2f", waitDigest.Quantile(0.5)),
+			fmt.Sprintf("%.2f", waitDigest.Quantile(0.9)),
+			fmt.Sprintf("%.2f", waitDigest.Quantile(0.99)),
+			fmt.Sprintf("%.2f", responseDigest.Quantile(0.5)),
+			fmt.Sprintf("%.2f", responseDigest.Quantile(0.9)),
+			fmt.Sprintf("%.2f", responseDigest.Quantile(0.99)),
 		})
 	}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+	_, _ = fmt.Fprintln(w, "Scheduler comparison")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Scheduler", "Ave Wait", "Ave Turnaround", "Throughput",
+		"p50 Wait", "p90 Wait", "p99 Wait", "p50 Response", "p90 Response", "p99 Response"})
+	table.AppendBulk(rows)
+	table.Render()
+}
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+// clonedProcesses returns a shallow copy of processes, since most schedulers
+// reorder their input slice in place.
+func clonedProcesses(processes []scheduler.Process) []scheduler.Process {
+	out := make([]scheduler.Process, len(processes))
+	copy(out, processes)
+	return out
 }
 
-func SJFSchedule(w io.Writer, title string, processes []Process) { 
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-	// Sort processes by burst duration in ascending order
-	sort.Slice(processes, func(i, j int) bool {
-		return processes[i].BurstDuration < processes[j].BurstDuration
-	})
-	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
-		}
-		totalWait += float64(waitingTime)
+//region Workload generation
+
+// runGenerate implements the "generate" subcommand: it produces a synthetic
+// CSV process file, in the format loadProcesses accepts, for stress-testing
+// the schedulers at sizes and burst-variances no hand-written CSV covers.
+func runGenerate(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	n := fs.Int("n", 10, "number of processes to generate")
+	arrival := fs.String("arrival", "uniform", "arrival distribution: uniform, poisson")
+	arrivalRate := fs.Float64("arrival-rate", 1, "mean arrival rate λ, used by -arrival poisson")
+	burst := fs.String("burst", "uniform", "burst distribution: constant, uniform, exponential, bimodal")
+	burstMean := fs.Float64("burst-mean", 5, "mean burst length, used by -burst constant and exponential")
+	burstMin := fs.Float64("burst-min", 1, "minimum burst length, used by -burst uniform")
+	burstMax := fs.Float64("burst-max", 10, "maximum burst length, used by -burst uniform")
+	burstLow := fs.Float64("burst-low", 2, "short-job burst length, used by -burst bimodal")
+	burstHigh := fs.Float64("burst-high", 20, "long-job burst length, used by -burst bimodal")
+	priorityRange := fs.String("priority-range", "1-4", "inclusive priority range, e.g. 1-4")
+	seed := fs.Int64("seed", 1, "PRNG seed, for reproducible workloads")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
-		start := waitingTime + processes[i].ArrivalTime
+	minPriority, maxPriority, err := parsePriorityRange(*priorityRange)
+	if err != nil {
+		return err
+	}
 
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
+	rng := rand.New(rand.NewSource(*seed))
 
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
+	arrivalFn, err := arrivalGenerator(*arrival, *arrivalRate, rng)
+	if err != nil {
+		return err
+	}
+	burstFn, err := burstGenerator(*burst, *burstMean, *burstMin, *burstMax, *burstLow, *burstHigh, rng)
+	if err != nil {
+		return err
+	}
 
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
+	cw := csv.NewWriter(w)
+	var arrivalTime int64
+	for i := 1; i <= *n; i++ {
+		arrivalTime += arrivalFn()
+		priority := minPriority + rng.Int63n(maxPriority-minPriority+1)
+		record := []string{
+			fmt.Sprint(i),
+			fmt.Sprint(burstFn()),
+			fmt.Sprint(arrivalTime),
+			fmt.Sprint(priority),
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("%w: writing generated process", err)
 		}
-		serviceTime += processes[i].BurstDuration
-
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
 	}
+	cw.Flush()
+	return cw.Error()
+}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+// arrivalGenerator returns a function producing successive inter-arrival
+// times under the requested distribution.
+func arrivalGenerator(name string, rate float64, rng *rand.Rand) (func() int64, error) {
+	switch name {
+	case "uniform":
+		return func() int64 { return rng.Int63n(3) }, nil
+	case "poisson":
+		return func() int64 { return int64(math.Round(-math.Log(1-rng.Float64()) / rate)) }, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown arrival distribution %q", ErrInvalidArgs, name)
+	}
 }
 
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-
-	// Sort processes by arrival time in ascending order
-	sort.Slice(processes, func(i, j int) bool {
-		return processes[i].ArrivalTime < processes[j].ArrivalTime
-	})
-
-	// Keep track of the index of the last process that has been executed
-	lastExecuted := 0
-
-	// Execute processes in order of arrival time until all have been executed
-	for len(processes) > lastExecuted {
-		// Find the process with the shortest remaining burst duration
-		nextProcess := -1
-		for i := lastExecuted; i < len(processes); i++ {
-			if processes[i].ArrivalTime <= serviceTime {
-				if nextProcess == -1 || processes[i].BurstDuration < processes[nextProcess].BurstDuration {
-					nextProcess = i
-				}
-			} else {
-				break
-			}
+// burstGenerator returns a function producing successive CPU burst lengths
+// (always at least 1) under the requested distribution.
+func burstGenerator(name string, mean, min, max, low, high float64, rng *rand.Rand) (func() int64, error) {
+	clamp := func(v float64) int64 {
+		if v < 1 {
+			return 1
 		}
-
-		if nextProcess == -1 {
-			// No process is available to execute, so skip ahead to the next arrival time
-			serviceTime = processes[lastExecuted].ArrivalTime
-		} else {
-			// Execute the selected process
-			p := processes[nextProcess]
-
-			if p.ArrivalTime > 0 {
-				waitingTime = serviceTime - p.ArrivalTime
+		return int64(math.Round(v))
+	}
+	switch name {
+	case "constant":
+		return func() int64 { return clamp(mean) }, nil
+	case "uniform":
+		return func() int64 { return clamp(min + rng.Float64()*(max-min)) }, nil
+	case "exponential":
+		return func() int64 { return clamp(-mean * math.Log(1-rng.Float64())) }, nil
+	case "bimodal":
+		return func() int64 {
+			if rng.Float64() < 0.5 {
+				return clamp(low)
 			}
-			totalWait += float64(waitingTime)
-
-			start := waitingTime + p.ArrivalTime
-
-			turnaround := p.BurstDuration + waitingTime
-			totalTurnaround += float64(turnaround)
-
-			completion := p.BurstDuration + p.ArrivalTime + waitingTime
-			lastCompletion = float64(completion)
+			return clamp(high)
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown burst distribution %q", ErrInvalidArgs, name)
+	}
+}
 
-			schedule[nextProcess] = []string{
-				fmt.Sprint(p.ProcessID),
-				fmt.Sprint(p.Priority),
-				fmt.Sprint(p.BurstDuration),
-				fmt.Sprint(p.ArrivalTime),
-				fmt.Sprint(waitingTime),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(completion),
-			}
-			serviceTime += p.BurstDuration
+// parsePriorityRange parses a "min-max" string into its inclusive bounds.
+func parsePriorityRange(s string) (min, max int64, err error) {
+	parts := strings.SplitN(s, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("%w: priority range must look like \"min-max\", got %q", ErrInvalidArgs, s)
+	}
+	min, err = strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: invalid priority range %q", err, s)
+	}
+	max, err = strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: invalid priority range %q", err, s)
+	}
+	if max < min {
+		return 0, 0, fmt.Errorf("%w: priority range %q has max below min", ErrInvalidArgs, s)
+	}
+	return min, max, nil
+}
 
-			gantt = append(gantt, TimeSlice{
-				PID:   p.ProcessID,
-				Start: start,
-				Stop:  serviceTime,
-			})
+//endregion
 
-			// Remove the executed process from the list of processes
-			processes[nextProcess] = processes[lastExecuted]
-			lastExecuted++
+func openProcessingFile(args []string) (*os.File, func(), error) {
+	if len(args) != 1 {
+		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
+	}
+	// Read in CSV process CSV file
+	f, err := os.Open(args[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	closeFn := func() {
+		if err := f.Close(); err != nil {
+			log.Fatalf("%v: error closing scheduling file", err)
 		}
 	}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return f, closeFn, nil
 }
 
-func RRSchedule(w io.Writer, title string, processes []Process, timeSlice float64) {
-	var (
-		currentTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		queue           = make([]Process, 0)
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-
-	// Sort processes by arrival time
-	sort.Slice(processes, func(i, j int) bool {
-		return processes[i].ArrivalTime < processes[j].ArrivalTime
-	})
-
-	// Run the scheduling algorithm
-	for len(queue) > 0 || len(processes) > 0 {
-		// Add any arriving processes to the queue
-		for len(processes) > 0 && processes[0].ArrivalTime <= currentTime {
-			queue = append(queue, processes[0])
-			processes = processes[1:]
-		}
+//region Output helpers
 
-		// If the queue is empty, jump to the next process arrival time
-		if len(queue) == 0 {
-			currentTime = processes[0].ArrivalTime
-		}
+// OutputFormat selects how Render writes a SchedulerResult.
+type OutputFormat int
 
-		// Get the next process in the queue
-		process := queue[0]
-		queue = queue[1:]
-
-		// Run the process for the time slice
-		var (
-			start       = currentTime
-			completion  int64
-			burstLeft   = process.BurstDuration
-			timeElapsed int64
-		)
-		for burstLeft > 0 {
-			// Use up the time slice or the remaining burst time, whichever is shorter
-			timeSpent := math.Min(float64(burstLeft), timeSlice)
-
-			// )Update the completion time and elapsed time
-			completion = int64(float64(currentTime) + timeSpent)
-			timeElapsed += int64(timeSpent)
-			currentTime = completion
-			burstLeft -= int64(timeSpent)
-
-			// Add to the Gantt chart
-			gantt = append(gantt, TimeSlice{
-				PID:   process.ProcessID,
-				Start: start,
-				Stop:  completion,
-			})
-
-			// Add any arriving processes to the queue
-			for len(processes) > 0 && processes[0].ArrivalTime <= currentTime {
-				queue = append(queue, processes[0])
-				processes = processes[1:]
-			}
-		}
+const (
+	FormatText OutputFormat = iota
+	FormatJSON
+	FormatCSV
+)
 
-		// Calculate waiting and turnaround time for the process
-		waitingTime := currentTime - process.ArrivalTime - process.BurstDuration
-		turnaround := waitingTime + process.BurstDuration + timeElapsed
-		totalWait += float64(waitingTime)
-		totalTurnaround += float64(turnaround)
-
-		// Add the process to the schedule table
-		schedule[process.ProcessID-1] = []string{
-			fmt.Sprint(process.ProcessID),
-			fmt.Sprint(process.Priority),
-			fmt.Sprint(process.BurstDuration),
-			fmt.Sprint(process.ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
-		}
+// ParseOutputFormat parses the -format flag value into an OutputFormat.
+func ParseOutputFormat(s string) (OutputFormat, error) {
+	switch strings.ToLower(s) {
+	case "", "text":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	default:
+		return 0, fmt.Errorf("%w: unknown output format %q", ErrInvalidArgs, s)
 	}
+}
 
-	// Calculate and output statistics
-	count := float64(len(schedule))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / float64(gantt[len(gantt)-1].Stop)
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+// Render writes a SchedulerResult to w in the requested format.
+func Render(w io.Writer, result scheduler.SchedulerResult, format OutputFormat) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	case FormatCSV:
+		return renderCSV(w, result)
+	default:
+		renderText(w, result)
+		return nil
+	}
 }
 
-//endregion
+func renderText(w io.Writer, result scheduler.SchedulerResult) {
+	outputTitle(w, result.Title)
+	outputGantt(w, result.Gantt)
+	outputSchedule(w, result.Rows, result.AveWait, result.AveTurnaround, result.AveResponse, result.AveThroughput)
+}
 
-//region Output helpers
+func renderCSV(w io.Writer, result scheduler.SchedulerResult) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"id", "priority", "burst", "arrival", "wait", "turnaround", "response", "completion"}); err != nil {
+		return fmt.Errorf("%w: writing CSV header", err)
+	}
+	for _, row := range result.Rows {
+		record := []string{
+			fmt.Sprint(row.PID),
+			fmt.Sprint(row.Priority),
+			fmt.Sprint(row.Burst),
+			fmt.Sprint(row.Arrival),
+			fmt.Sprint(row.Wait),
+			fmt.Sprint(row.Turnaround),
+			fmt.Sprint(row.Response()),
+			fmt.Sprint(row.Completion),
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("%w: writing CSV row", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
 
 func outputTitle(w io.Writer, title string) {
 	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
@@ -377,13 +433,17 @@ func outputTitle(w io.Writer, title string) {
 	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
 }
 
-func outputGantt(w io.Writer, gantt []TimeSlice) {
+func outputGantt(w io.Writer, gantt []scheduler.TimeSlice) {
 	_, _ = fmt.Fprintln(w, "Gantt schedule")
 	_, _ = fmt.Fprint(w, "|")
 	for i := range gantt {
-		pid := fmt.Sprint(gantt[i].PID)
-		padding := strings.Repeat(" ", (8-len(pid))/2)
-		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
+		label := ganttLabel(gantt[i])
+		padWidth := (8 - len(label)) / 2
+		if padWidth < 0 {
+			padWidth = 0
+		}
+		padding := strings.Repeat(" ", padWidth)
+		_, _ = fmt.Fprint(w, padding, label, padding, "|")
 	}
 	_, _ = fmt.Fprintln(w)
 	for i := range gantt {
@@ -395,14 +455,39 @@ func outputGantt(w io.Writer, gantt []TimeSlice) {
 	_, _ = fmt.Fprintf(w, "\n\n")
 }
 
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
+// ganttLabel renders the PID for CPU slices, or a kind marker for IO waits
+// and idle time, on the Gantt chart.
+func ganttLabel(slice scheduler.TimeSlice) string {
+	switch slice.Kind {
+	case scheduler.SliceIO:
+		return fmt.Sprintf("IO(%d)", slice.PID)
+	case scheduler.SliceIdle:
+		return "IDLE"
+	default:
+		return fmt.Sprint(slice.PID)
+	}
+}
+
+func outputSchedule(w io.Writer, rows []scheduler.ProcessMetrics, wait, turnaround, response, throughput float64) {
 	_, _ = fmt.Fprintln(w, "Schedule table")
 	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
-	table.AppendBulk(rows)
+	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Response", "Exit"})
+	for _, row := range rows {
+		table.Append([]string{
+			fmt.Sprint(row.PID),
+			fmt.Sprint(row.Priority),
+			fmt.Sprint(row.Burst),
+			fmt.Sprint(row.Arrival),
+			fmt.Sprint(row.Wait),
+			fmt.Sprint(row.Turnaround),
+			fmt.Sprint(row.Response()),
+			fmt.Sprint(row.Completion),
+		})
+	}
 	table.SetFooter([]string{"", "", "", "",
 		fmt.Sprintf("Average\n%.2f", wait),
 		fmt.Sprintf("Average\n%.2f", turnaround),
+		fmt.Sprintf("Average\n%.2f", response),
 		fmt.Sprintf("Throughput\n%.2f/t", throughput)})
 	table.Render()
 }
@@ -412,25 +497,52 @@ func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput f
 //region Loading processes.
 var ErrInvalidArgs = errors.New("invalid args")
 
-func loadProcesses(r io.Reader) ([]Process, error) {
+func loadProcesses(r io.Reader) ([]scheduler.Process, error) {
 	rows, err := csv.NewReader(r).ReadAll()
 	if err != nil {
 		return nil, fmt.Errorf("%w: reading CSV", err)
 	}
 
-	processes := make([]Process, len(rows))
+	processes := make([]scheduler.Process, len(rows))
 	for i := range rows {
 		processes[i].ProcessID = mustStrToInt(rows[i][0])
 		processes[i].BurstDuration = mustStrToInt(rows[i][1])
 		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
+		if len(rows[i]) >= 4 {
 			processes[i].Priority = mustStrToInt(rows[i][3])
 		}
+		if len(rows[i]) >= 5 && rows[i][4] != "" {
+			bursts, err := parseIOBursts(rows[i][4])
+			if err != nil {
+				return nil, fmt.Errorf("%w: parsing IO bursts for process %d", err, processes[i].ProcessID)
+			}
+			processes[i].IOBursts = bursts
+		}
 	}
 
 	return processes, nil
 }
 
+// parseIOBursts parses a "CPU,IO,CPU,..." column, e.g. "5,3,4", into the
+// alternating segment lengths used by MLFQSchedule. The list must start and
+// end on a CPU segment, so it always has an odd number of entries.
+func parseIOBursts(s string) ([]int64, error) {
+	parts := strings.Split(s, ",")
+	bursts := make([]int64, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseInt(strings.TrimSpace(p), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid IO burst segment %q", err, p)
+		}
+		bursts[i] = n
+	}
+	if len(bursts)%2 == 0 {
+		return nil, fmt.Errorf("%w: IO bursts must start and end with a CPU segment", ErrInvalidArgs)
+	}
+
+	return bursts, nil
+}
+
 func mustStrToInt(s string) int64 {
 	i, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Cookiefied/ProcessScheduler/scheduler"
+)
+
+func TestSchedulers(t *testing.T) {
+	tests := []struct {
+		name         string
+		run          func([]scheduler.Process) scheduler.SchedulerResult
+		processes    []scheduler.Process
+		wantWait     float64
+		wantTurn     float64
+		wantResponse float64
+	}{
+		{
+			name: "FCFS with staggered arrivals",
+			run:  func(p []scheduler.Process) scheduler.SchedulerResult { return scheduler.FCFSSchedule("FCFS", p) },
+			processes: []scheduler.Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 3, ArrivalTime: 1},
+				{ProcessID: 3, BurstDuration: 8, ArrivalTime: 2},
+			},
+			// P1: 0..5 (wait 0), P2: 5..8 (wait 4), P3: 8..16 (wait 6)
+			wantWait:     10.0 / 3.0,
+			wantTurn:     26.0 / 3.0,
+			wantResponse: 10.0 / 3.0,
+		},
+		{
+			name: "SJF with staggered arrivals",
+			run:  func(p []scheduler.Process) scheduler.SchedulerResult { return scheduler.SJFSchedule("SJF", p) },
+			processes: []scheduler.Process{
+				{ProcessID: 1, BurstDuration: 3, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 5, ArrivalTime: 2},
+				{ProcessID: 3, BurstDuration: 9, ArrivalTime: 6},
+			},
+			// Already shortest-burst-first order: P1: 0..3 (wait 0),
+			// P2: 3..8 (wait 1), P3: 8..17 (wait 2)
+			wantWait:     1,
+			wantTurn:     20.0 / 3.0,
+			wantResponse: 1,
+		},
+		{
+			// Silberschatz's canonical RR(quantum=4) example.
+			name: "RR textbook workload",
+			run:  func(p []scheduler.Process) scheduler.SchedulerResult { return scheduler.RRSchedule("RR", p, 4) },
+			processes: []scheduler.Process{
+				{ProcessID: 1, BurstDuration: 24, ArrivalTime: 0},
+				{ProcessID: 2, BurstDuration: 3, ArrivalTime: 0},
+				{ProcessID: 3, BurstDuration: 3, ArrivalTime: 0},
+			},
+			// P1 waits 6, P2 waits 4, P3 waits 7 -> average 17/3
+			wantWait:     17.0 / 3.0,
+			wantTurn:     47.0 / 3.0,
+			wantResponse: 11.0 / 3.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.run(tt.processes)
+
+			if !almostEqual(result.AveWait, tt.wantWait) {
+				t.Errorf("AveWait = %v, want %v", result.AveWait, tt.wantWait)
+			}
+			if !almostEqual(result.AveTurnaround, tt.wantTurn) {
+				t.Errorf("AveTurnaround = %v, want %v", result.AveTurnaround, tt.wantTurn)
+			}
+			if !almostEqual(result.AveResponse, tt.wantResponse) {
+				t.Errorf("AveResponse = %v, want %v", result.AveResponse, tt.wantResponse)
+			}
+		})
+	}
+}
+
+// TestRRWaitingTimeNeverNegative guards against the regression this suite
+// was added for: the old RR waiting-time formula could go negative once a
+// job's completion time diverged from the tick it last ran.
+func TestRRWaitingTimeNeverNegative(t *testing.T) {
+	processes := []scheduler.Process{
+		{ProcessID: 1, BurstDuration: 10, ArrivalTime: 0},
+		{ProcessID: 2, BurstDuration: 4, ArrivalTime: 1},
+		{ProcessID: 3, BurstDuration: 6, ArrivalTime: 2},
+	}
+
+	result := scheduler.RRSchedule("RR", processes, 3)
+	for _, row := range result.Rows {
+		if row.Wait < 0 {
+			t.Errorf("process %d has negative wait time %d", row.PID, row.Wait)
+		}
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	const epsilon = 1e-9
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff < epsilon
+}
@@ -0,0 +1,198 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// EventKind identifies what happened to a process at a given tick.
+type EventKind int
+
+const (
+	Dispatch EventKind = iota
+	Preempt
+	Complete
+	Arrive
+	IOStart
+	IOEnd
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case Dispatch:
+		return "dispatch"
+	case Preempt:
+		return "preempt"
+	case Complete:
+		return "complete"
+	case Arrive:
+		return "arrive"
+	case IOStart:
+		return "io_start"
+	case IOEnd:
+		return "io_end"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a single thing that happened to a process at a tick, as replayed
+// by an Engine from a computed SchedulerResult.
+type Event struct {
+	Tick int64
+	Kind EventKind
+	PID  int64
+}
+
+// MarshalJSON renders Kind as its string name rather than its underlying
+// int, since Events are consumed by the SSE browser client as JSON.
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Tick int64  `json:"tick"`
+		Kind string `json:"kind"`
+		PID  int64  `json:"pid"`
+	}{Tick: e.Tick, Kind: e.Kind.String(), PID: e.PID})
+}
+
+// Algorithm names a scheduling strategy an Engine can run. Every scheduler
+// in this package computes its whole schedule up front; an Algorithm is
+// just that computation wrapped with the display name it should be
+// replayed under.
+type Algorithm struct {
+	Name string
+	Run  func(processes []Process) SchedulerResult
+}
+
+// Engine is a deliberate batch-compute-then-replay design, not a tick-by-
+// tick incremental scheduler: Run calls algorithm.Run once up front to get
+// the whole SchedulerResult, then paces the Events derived from it by
+// TickDuration. A genuinely incremental engine would need every algorithm
+// in this package to expose resumable per-tick state (e.g. a Step method)
+// instead of the single batch Run func Algorithm wraps today, which none
+// of the five scheduling functions do. Replaying a batch result was chosen
+// over that rewrite so both the terminal's -live mode and -serve's SSE
+// stream could ship against the existing algorithms; Heartbeat below is
+// what lets either consumer see queue depth between Events despite the
+// schedule already being fully known. A zero TickDuration replays every
+// event immediately.
+type Engine struct {
+	TickDuration time.Duration
+
+	// HeartbeatEvery, if positive, makes Run spawn a goroutine that calls
+	// Heartbeat every HeartbeatEvery simulated ticks with the PIDs that have
+	// arrived but not yet completed, so a slow consumer can show queue depth
+	// between Events. Ignored if Heartbeat is nil or TickDuration is zero.
+	HeartbeatEvery int64
+	Heartbeat      func(tick int64, waiting []int64)
+}
+
+// Run computes algorithm's schedule for processes up front (see the Engine
+// doc comment for why this is a replay, not a live tick-by-tick
+// simulation), then replays it as Events on the returned channel, paced by
+// TickDuration. The channel is closed once the schedule finishes replaying
+// or ctx is cancelled. The SchedulerResult is returned immediately so a
+// consumer that wants the whole table up front (not just the live stream)
+// still gets it.
+func (e *Engine) Run(ctx context.Context, algorithm Algorithm, processes []Process) (<-chan Event, SchedulerResult) {
+	result := algorithm.Run(processes)
+	events := deriveEvents(processes, result)
+
+	out := make(chan Event)
+	go func() {
+		defer close(out)
+
+		var stop chan struct{}
+		if e.Heartbeat != nil && e.HeartbeatEvery > 0 && e.TickDuration > 0 {
+			stop = make(chan struct{})
+			go e.runHeartbeat(ctx, stop, processes, completionByPID(result.Rows))
+			defer close(stop)
+		}
+
+		var lastTick int64
+		for _, ev := range events {
+			if e.TickDuration > 0 && ev.Tick > lastTick {
+				select {
+				case <-time.After(time.Duration(ev.Tick-lastTick) * e.TickDuration):
+				case <-ctx.Done():
+					return
+				}
+			}
+			lastTick = ev.Tick
+
+			select {
+			case out <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, result
+}
+
+// runHeartbeat calls e.Heartbeat at real-time intervals of
+// HeartbeatEvery*TickDuration, reporting which PIDs have arrived but not
+// yet completed as of the simulated tick that interval corresponds to.
+func (e *Engine) runHeartbeat(ctx context.Context, stop <-chan struct{}, processes []Process, completion map[int64]int64) {
+	ticker := time.NewTicker(time.Duration(e.HeartbeatEvery) * e.TickDuration)
+	defer ticker.Stop()
+
+	var tick int64
+	for {
+		select {
+		case <-ticker.C:
+			tick += e.HeartbeatEvery
+			var waiting []int64
+			for _, p := range processes {
+				if p.ArrivalTime <= tick && completion[p.ProcessID] > tick {
+					waiting = append(waiting, p.ProcessID)
+				}
+			}
+			e.Heartbeat(tick, waiting)
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// deriveEvents reconstructs the tick-by-tick Events a SchedulerResult
+// implies: an Arrive for each process, a Dispatch/Preempt-or-Complete pair
+// for each CPU slice, and an IOStart/IOEnd pair for each IO slice. Idle
+// slices have no PID and so emit nothing.
+func deriveEvents(processes []Process, result SchedulerResult) []Event {
+	completion := completionByPID(result.Rows)
+
+	events := make([]Event, 0, len(processes)+2*len(result.Gantt))
+	for _, p := range processes {
+		events = append(events, Event{Tick: p.ArrivalTime, Kind: Arrive, PID: p.ProcessID})
+	}
+	for _, slice := range result.Gantt {
+		switch slice.Kind {
+		case SliceCPU:
+			events = append(events, Event{Tick: slice.Start, Kind: Dispatch, PID: slice.PID})
+			kind := Preempt
+			if slice.Stop == completion[slice.PID] {
+				kind = Complete
+			}
+			events = append(events, Event{Tick: slice.Stop, Kind: kind, PID: slice.PID})
+		case SliceIO:
+			events = append(events, Event{Tick: slice.Start, Kind: IOStart, PID: slice.PID})
+			events = append(events, Event{Tick: slice.Stop, Kind: IOEnd, PID: slice.PID})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Tick < events[j].Tick })
+	return events
+}
+
+// completionByPID indexes a schedule table's completion ticks by PID.
+func completionByPID(rows []ProcessMetrics) map[int64]int64 {
+	m := make(map[int64]int64, len(rows))
+	for _, r := range rows {
+		m[r.PID] = r.Completion
+	}
+	return m
+}
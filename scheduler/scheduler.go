@@ -0,0 +1,650 @@
+// Package scheduler implements the CPU scheduling algorithms this program
+// knows about — FCFS, SJF, SJF with priority, round-robin and multi-level
+// feedback queue — each computing a full SchedulerResult for a batch of
+// processes. Engine (in engine.go) replays a computed SchedulerResult as a
+// live, tick-by-tick stream of Events for consumers that want to watch a
+// schedule happen rather than read the finished table.
+package scheduler
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+)
+
+type (
+	Process struct {
+		ProcessID     int64
+		ArrivalTime   int64
+		BurstDuration int64
+		Priority      int64
+		// IOBursts holds alternating CPU/IO segment lengths, starting and ending
+		// with a CPU segment, e.g. []int64{5, 3, 4} means "5 CPU, 3 IO, 4 CPU".
+		// Nil means the process is a single CPU burst of BurstDuration.
+		IOBursts []int64
+	}
+	// SliceKind distinguishes what a TimeSlice represents on the Gantt chart.
+	SliceKind int
+	TimeSlice struct {
+		PID   int64     `json:"pid"`
+		Start int64     `json:"start"`
+		Stop  int64     `json:"stop"`
+		Kind  SliceKind `json:"kind"`
+	}
+)
+
+const (
+	SliceCPU SliceKind = iota
+	SliceIO
+	SliceIdle
+)
+
+func (k SliceKind) String() string {
+	switch k {
+	case SliceCPU:
+		return "cpu"
+	case SliceIO:
+		return "io"
+	case SliceIdle:
+		return "idle"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON renders Kind as its string name rather than its underlying
+// int, matching how scheduler.Event renders EventKind.
+func (k SliceKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+// ProcessMetrics is the per-process row of a SchedulerResult's schedule table.
+type ProcessMetrics struct {
+	PID        int64 `json:"pid"`
+	Priority   int64 `json:"priority"`
+	Burst      int64 `json:"burst"`
+	Arrival    int64 `json:"arrival"`
+	Wait       int64 `json:"wait"`
+	Turnaround int64 `json:"turnaround"`
+	// FirstStart is the tick at which the process was first dispatched to the
+	// CPU; Response (FirstStart - Arrival) is what distinguishes RR from SJF.
+	FirstStart int64 `json:"firstStart"`
+	Completion int64 `json:"completion"`
+}
+
+// Response returns how long the process waited between arrival and its
+// first dispatch.
+func (m ProcessMetrics) Response() int64 {
+	return m.FirstStart - m.Arrival
+}
+
+// averageResponse computes the mean response time across a schedule table.
+func averageResponse(rows []ProcessMetrics) float64 {
+	if len(rows) == 0 {
+		return 0
+	}
+	var total float64
+	for _, r := range rows {
+		total += float64(r.Response())
+	}
+	return total / float64(len(rows))
+}
+
+// SchedulerResult is what a scheduler computes: the Gantt chart, the
+// per-process metrics table, and the aggregate averages. It carries no
+// knowledge of how it will be rendered.
+type SchedulerResult struct {
+	Title         string           `json:"title"`
+	Gantt         []TimeSlice      `json:"gantt"`
+	Rows          []ProcessMetrics `json:"rows"`
+	AveWait       float64          `json:"aveWait"`
+	AveTurnaround float64          `json:"aveTurnaround"`
+	AveThroughput float64          `json:"aveThroughput"`
+	AveResponse   float64          `json:"aveResponse"`
+}
+
+// FCFSSchedule computes a schedule of processes in First-Come, First-Served order given:
+// • a title for the chart
+// • a slice of processes
+func FCFSSchedule(title string, processes []Process) SchedulerResult {
+	var (
+		serviceTime     int64
+		totalWait       float64
+		totalTurnaround float64
+		lastCompletion  float64
+		waitingTime     int64
+		rows            = make([]ProcessMetrics, len(processes))
+		gantt           = make([]TimeSlice, 0)
+	)
+	for i := range processes {
+		// The CPU idles until the process arrives if it is free before then
+		if serviceTime < processes[i].ArrivalTime {
+			serviceTime = processes[i].ArrivalTime
+		}
+		waitingTime = serviceTime - processes[i].ArrivalTime
+		totalWait += float64(waitingTime)
+
+		start := waitingTime + processes[i].ArrivalTime
+
+		turnaround := processes[i].BurstDuration + waitingTime
+		totalTurnaround += float64(turnaround)
+
+		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
+		lastCompletion = float64(completion)
+
+		rows[i] = ProcessMetrics{
+			PID:        processes[i].ProcessID,
+			Priority:   processes[i].Priority,
+			Burst:      processes[i].BurstDuration,
+			Arrival:    processes[i].ArrivalTime,
+			Wait:       waitingTime,
+			Turnaround: turnaround,
+			FirstStart: start,
+			Completion: completion,
+		}
+		serviceTime += processes[i].BurstDuration
+
+		gantt = append(gantt, TimeSlice{
+			PID:   processes[i].ProcessID,
+			Start: start,
+			Stop:  serviceTime,
+		})
+	}
+
+	count := float64(len(processes))
+
+	return SchedulerResult{
+		Title:         title,
+		Gantt:         gantt,
+		Rows:          rows,
+		AveWait:       totalWait / count,
+		AveTurnaround: totalTurnaround / count,
+		AveThroughput: count / lastCompletion,
+		AveResponse:   averageResponse(rows),
+	}
+}
+
+// SJFSchedule computes a schedule of processes in Shortest-Job-First order given:
+// • a title for the chart
+// • a slice of processes
+func SJFSchedule(title string, processes []Process) SchedulerResult {
+	var (
+		serviceTime     int64
+		totalWait       float64
+		totalTurnaround float64
+		lastCompletion  float64
+		waitingTime     int64
+		rows            = make([]ProcessMetrics, len(processes))
+		gantt           = make([]TimeSlice, 0)
+	)
+	// Sort processes by burst duration in ascending order
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].BurstDuration < processes[j].BurstDuration
+	})
+	for i := range processes {
+		// The CPU idles until the process arrives if it is free before then
+		if serviceTime < processes[i].ArrivalTime {
+			serviceTime = processes[i].ArrivalTime
+		}
+		waitingTime = serviceTime - processes[i].ArrivalTime
+		totalWait += float64(waitingTime)
+
+		start := waitingTime + processes[i].ArrivalTime
+
+		turnaround := processes[i].BurstDuration + waitingTime
+		totalTurnaround += float64(turnaround)
+
+		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
+		lastCompletion = float64(completion)
+
+		rows[i] = ProcessMetrics{
+			PID:        processes[i].ProcessID,
+			Priority:   processes[i].Priority,
+			Burst:      processes[i].BurstDuration,
+			Arrival:    processes[i].ArrivalTime,
+			Wait:       waitingTime,
+			Turnaround: turnaround,
+			FirstStart: start,
+			Completion: completion,
+		}
+		serviceTime += processes[i].BurstDuration
+
+		gantt = append(gantt, TimeSlice{
+			PID:   processes[i].ProcessID,
+			Start: start,
+			Stop:  serviceTime,
+		})
+	}
+
+	count := float64(len(processes))
+
+	return SchedulerResult{
+		Title:         title,
+		Gantt:         gantt,
+		Rows:          rows,
+		AveWait:       totalWait / count,
+		AveTurnaround: totalTurnaround / count,
+		AveThroughput: count / lastCompletion,
+		AveResponse:   averageResponse(rows),
+	}
+}
+
+// SJFPrioritySchedule computes a non-preemptive schedule that, each time the
+// CPU frees up, runs the shortest job among the processes that have arrived;
+// Priority is not consulted. Given:
+// • a title for the chart
+// • a slice of processes
+func SJFPrioritySchedule(title string, processes []Process) SchedulerResult {
+	var (
+		serviceTime     int64
+		totalWait       float64
+		totalTurnaround float64
+		lastCompletion  float64
+		waitingTime     int64
+		rows            = make([]ProcessMetrics, len(processes))
+		gantt           = make([]TimeSlice, 0)
+	)
+
+	// Sort processes by arrival time in ascending order
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].ArrivalTime < processes[j].ArrivalTime
+	})
+
+	// Keep track of the index of the last process that has been executed
+	lastExecuted := 0
+
+	// Execute processes in order of arrival time until all have been executed
+	for len(processes) > lastExecuted {
+		// Find the process with the shortest remaining burst duration
+		nextProcess := -1
+		for i := lastExecuted; i < len(processes); i++ {
+			if processes[i].ArrivalTime <= serviceTime {
+				if nextProcess == -1 || processes[i].BurstDuration < processes[nextProcess].BurstDuration {
+					nextProcess = i
+				}
+			} else {
+				break
+			}
+		}
+
+		if nextProcess == -1 {
+			// No process is available to execute, so skip ahead to the next arrival time
+			serviceTime = processes[lastExecuted].ArrivalTime
+		} else {
+			// Execute the selected process
+			p := processes[nextProcess]
+
+			waitingTime = serviceTime - p.ArrivalTime
+			totalWait += float64(waitingTime)
+
+			start := waitingTime + p.ArrivalTime
+
+			turnaround := p.BurstDuration + waitingTime
+			totalTurnaround += float64(turnaround)
+
+			completion := p.BurstDuration + p.ArrivalTime + waitingTime
+			lastCompletion = float64(completion)
+
+			rows[lastExecuted] = ProcessMetrics{
+				PID:        p.ProcessID,
+				Priority:   p.Priority,
+				Burst:      p.BurstDuration,
+				Arrival:    p.ArrivalTime,
+				Wait:       waitingTime,
+				Turnaround: turnaround,
+				FirstStart: start,
+				Completion: completion,
+			}
+			serviceTime += p.BurstDuration
+
+			gantt = append(gantt, TimeSlice{
+				PID:   p.ProcessID,
+				Start: start,
+				Stop:  serviceTime,
+			})
+
+			// Remove the executed process from the list of processes
+			processes[nextProcess], processes[lastExecuted] = processes[lastExecuted], processes[nextProcess]
+			lastExecuted++
+		}
+	}
+
+	count := float64(len(processes))
+
+	return SchedulerResult{
+		Title:         title,
+		Gantt:         gantt,
+		Rows:          rows,
+		AveWait:       totalWait / count,
+		AveTurnaround: totalTurnaround / count,
+		AveThroughput: count / lastCompletion,
+		AveResponse:   averageResponse(rows),
+	}
+}
+
+// rrJob tracks the remaining burst and first-dispatch tick for a process
+// as RRSchedule preempts and requeues it across quanta.
+type rrJob struct {
+	process    Process
+	remaining  int64
+	started    bool
+	firstStart int64
+}
+
+// RRSchedule computes a schedule of processes in Round-Robin order given:
+// • a title for the chart
+// • a slice of processes
+// • timeSlice, the quantum each process runs for before being preempted
+func RRSchedule(title string, processes []Process, timeSlice float64) SchedulerResult {
+	// Sort processes by arrival time
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].ArrivalTime < processes[j].ArrivalTime
+	})
+
+	var (
+		currentTime     int64
+		totalWait       float64
+		totalTurnaround float64
+		pending         = make([]*rrJob, len(processes))
+		queue           = make([]*rrJob, 0)
+		rows            = make([]ProcessMetrics, len(processes))
+		gantt           = make([]TimeSlice, 0)
+	)
+	for i := range processes {
+		pending[i] = &rrJob{process: processes[i], remaining: processes[i].BurstDuration}
+	}
+
+	// Run the scheduling algorithm
+	for len(queue) > 0 || len(pending) > 0 {
+		// Add any arriving processes to the queue
+		for len(pending) > 0 && pending[0].process.ArrivalTime <= currentTime {
+			queue = append(queue, pending[0])
+			pending = pending[1:]
+		}
+
+		// If the queue is empty, jump to the next process arrival time
+		if len(queue) == 0 {
+			currentTime = pending[0].process.ArrivalTime
+			continue
+		}
+
+		// Get the next process in the queue
+		job := queue[0]
+		queue = queue[1:]
+
+		if !job.started {
+			job.started = true
+			job.firstStart = currentTime
+		}
+
+		// Run the process for one time slice, or until its burst finishes
+		start := currentTime
+		timeSpent := int64(math.Min(float64(job.remaining), timeSlice))
+		currentTime += timeSpent
+		job.remaining -= timeSpent
+
+		// Add to the Gantt chart
+		gantt = append(gantt, TimeSlice{
+			PID:   job.process.ProcessID,
+			Start: start,
+			Stop:  currentTime,
+		})
+
+		// Add any arriving processes to the queue before this job, if it still has work left
+		for len(pending) > 0 && pending[0].process.ArrivalTime <= currentTime {
+			queue = append(queue, pending[0])
+			pending = pending[1:]
+		}
+
+		if job.remaining > 0 {
+			// Quantum expired before the burst finished: go to the back of the queue
+			queue = append(queue, job)
+			continue
+		}
+
+		// Calculate waiting and turnaround time for the process
+		completion := currentTime
+		waitingTime := completion - job.process.ArrivalTime - job.process.BurstDuration
+		turnaround := completion - job.process.ArrivalTime
+		totalWait += float64(waitingTime)
+		totalTurnaround += float64(turnaround)
+
+		// Add the process to the schedule table
+		rows[job.process.ProcessID-1] = ProcessMetrics{
+			PID:        job.process.ProcessID,
+			Priority:   job.process.Priority,
+			Burst:      job.process.BurstDuration,
+			Arrival:    job.process.ArrivalTime,
+			Wait:       waitingTime,
+			Turnaround: turnaround,
+			FirstStart: job.firstStart,
+			Completion: completion,
+		}
+	}
+
+	// Calculate statistics
+	count := float64(len(rows))
+
+	return SchedulerResult{
+		Title:         title,
+		Gantt:         gantt,
+		Rows:          rows,
+		AveWait:       totalWait / count,
+		AveTurnaround: totalTurnaround / count,
+		AveThroughput: count / float64(gantt[len(gantt)-1].Stop),
+		AveResponse:   averageResponse(rows),
+	}
+}
+
+// mlfqJob tracks the state MLFQSchedule needs for a single process as it
+// moves between queues, runs its CPU segments and blocks on IO.
+type mlfqJob struct {
+	process    Process
+	segments   []int64 // alternating CPU, IO, CPU, ... lengths; always starts/ends on CPU
+	segIdx     int
+	remain     int64 // time left in the current segment
+	queue      int
+	ioStart    int64
+	ioUntil    int64
+	started    bool
+	firstStart int64
+}
+
+func (j *mlfqJob) totalBurst() int64 {
+	var total int64
+	for i, seg := range j.segments {
+		if i%2 == 0 {
+			total += seg
+		}
+	}
+	return total
+}
+
+// MLFQSchedule computes a schedule for a multi-level feedback queue given:
+// • a title for the chart
+// • a slice of processes, optionally carrying IOBursts
+// • quanta, the time quantum for each queue level (index 0 is highest priority)
+// • boostInterval, how often (in ticks) all jobs are returned to queue 0 to
+//   prevent starvation; 0 disables boosting
+func MLFQSchedule(title string, processes []Process, quanta []int64, boostInterval int64) SchedulerResult {
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].ArrivalTime < processes[j].ArrivalTime
+	})
+
+	pending := make([]*mlfqJob, len(processes))
+	for i := range processes {
+		segments := processes[i].IOBursts
+		if len(segments) == 0 {
+			segments = []int64{processes[i].BurstDuration}
+		}
+		pending[i] = &mlfqJob{process: processes[i], segments: segments, remain: segments[0]}
+	}
+
+	var (
+		currentTime  int64
+		lastBoost    int64
+		completed    int
+		blocked      = make([]*mlfqJob, 0)
+		queues       = make([][]*mlfqJob, len(quanta))
+		gantt        = make([]TimeSlice, 0)
+		rows         = make([]ProcessMetrics, 0, len(processes))
+		totalWait    float64
+		totalTurn    float64
+		lastComplete float64
+	)
+
+	for completed < len(processes) {
+		// Arrivals join the top queue.
+		for len(pending) > 0 && pending[0].process.ArrivalTime <= currentTime {
+			queues[0] = append(queues[0], pending[0])
+			pending = pending[1:]
+		}
+
+		// IO completions rejoin the queue they were blocked from, advancing
+		// past the IO segment to the CPU segment that follows it; job.remain
+		// was holding the IO segment's length, not the next CPU burst's.
+		stillBlocked := blocked[:0]
+		for _, job := range blocked {
+			if job.ioUntil <= currentTime {
+				gantt = append(gantt, TimeSlice{PID: job.process.ProcessID, Start: job.ioStart, Stop: job.ioUntil, Kind: SliceIO})
+				job.segIdx++
+				job.remain = job.segments[job.segIdx]
+				queues[job.queue] = append(queues[job.queue], job)
+			} else {
+				stillBlocked = append(stillBlocked, job)
+			}
+		}
+		blocked = stillBlocked
+
+		// Periodic priority boost moves every waiting job back to queue 0,
+		// including jobs currently blocked on IO, so a job doesn't lose the
+		// boost just because it was mid-burst when it fired. We track the
+		// tick it last fired rather than checking currentTime for an exact
+		// multiple of boostInterval, since currentTime advances by irregular
+		// amounts (a quantum, an IO burst, an idle skip) and routinely steps
+		// over exact multiples without ever landing on one.
+		if boostInterval > 0 && currentTime-lastBoost >= boostInterval {
+			lastBoost = currentTime
+			for level := 1; level < len(queues); level++ {
+				for _, job := range queues[level] {
+					job.queue = 0
+					queues[0] = append(queues[0], job)
+				}
+				queues[level] = nil
+			}
+			for _, job := range blocked {
+				job.queue = 0
+			}
+		}
+
+		level := -1
+		for i, q := range queues {
+			if len(q) > 0 {
+				level = i
+				break
+			}
+		}
+
+		if level == -1 {
+			// Nothing runnable: idle until the next arrival or IO completion.
+			next := nextMLFQEvent(pending, blocked)
+			if next <= currentTime {
+				next = currentTime + 1
+			}
+			gantt = append(gantt, TimeSlice{Start: currentTime, Stop: next, Kind: SliceIdle})
+			currentTime = next
+			continue
+		}
+
+		job := queues[level][0]
+		queues[level] = queues[level][1:]
+
+		if !job.started {
+			job.started = true
+			job.firstStart = currentTime
+		}
+
+		quantum := quanta[level]
+		runFor := job.remain
+		if quantum < runFor {
+			runFor = quantum
+		}
+
+		start := currentTime
+		currentTime += runFor
+		job.remain -= runFor
+		gantt = append(gantt, TimeSlice{PID: job.process.ProcessID, Start: start, Stop: currentTime, Kind: SliceCPU})
+
+		if job.remain > 0 {
+			// Quantum expired before the burst finished: demote.
+			job.queue = min(level+1, len(queues)-1)
+			queues[job.queue] = append(queues[job.queue], job)
+			continue
+		}
+
+		// This CPU segment finished; move on to IO, the next segment, or completion.
+		job.segIdx++
+		if job.segIdx >= len(job.segments) {
+			completion := currentTime
+			arrival := job.process.ArrivalTime
+			waitingTime := completion - arrival - job.totalBurst()
+			turnaround := completion - arrival
+			totalWait += float64(waitingTime)
+			totalTurn += float64(turnaround)
+			lastComplete = float64(completion)
+			completed++
+
+			rows = append(rows, ProcessMetrics{
+				PID:        job.process.ProcessID,
+				Priority:   job.process.Priority,
+				Burst:      job.totalBurst(),
+				Arrival:    arrival,
+				Wait:       waitingTime,
+				Turnaround: turnaround,
+				FirstStart: job.firstStart,
+				Completion: completion,
+			})
+			continue
+		}
+
+		// Next segment is IO: block the job without demoting it.
+		job.remain = job.segments[job.segIdx]
+		job.ioStart = currentTime
+		job.ioUntil = currentTime + job.remain
+		blocked = append(blocked, job)
+	}
+
+	count := float64(len(processes))
+
+	return SchedulerResult{
+		Title:         title,
+		Gantt:         gantt,
+		Rows:          rows,
+		AveWait:       totalWait / count,
+		AveTurnaround: totalTurn / count,
+		AveThroughput: count / lastComplete,
+		AveResponse:   averageResponse(rows),
+	}
+}
+
+// nextMLFQEvent returns the earliest tick at which something becomes
+// runnable again: the next arrival or the next IO completion.
+func nextMLFQEvent(pending []*mlfqJob, blocked []*mlfqJob) int64 {
+	next := int64(math.MaxInt64)
+	if len(pending) > 0 && pending[0].process.ArrivalTime < next {
+		next = pending[0].process.ArrivalTime
+	}
+	for _, job := range blocked {
+		if job.ioUntil < next {
+			next = job.ioUntil
+		}
+	}
+	return next
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
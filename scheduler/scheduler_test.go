@@ -0,0 +1,68 @@
+package scheduler
+
+import "testing"
+
+// TestMLFQSchedule exercises a two-level MLFQ with a quantum short enough to
+// demote P1, an IO-bearing P2 that blocks and rejoins its own queue, and a
+// boost interval short enough to fire mid-run and rescue the demoted P1 —
+// the three behaviors the request asked for (demotion, IO blocking without
+// demotion, anti-starvation boosting) in one canonical, hand-traced case.
+func TestMLFQSchedule(t *testing.T) {
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4, IOBursts: []int64{2, 3, 2}},
+	}
+
+	result := MLFQSchedule("MLFQ", processes, []int64{2, 4}, 6)
+
+	const (
+		wantWait     = 4.5  // (P2: 5, P1: 4) / 2
+		wantTurn     = 10.5 // (P2: 9, P1: 12) / 2
+		wantResponse = 0.5  // (P1: 0, P2: 1) / 2
+	)
+
+	if !almostEqual(result.AveWait, wantWait) {
+		t.Errorf("AveWait = %v, want %v", result.AveWait, wantWait)
+	}
+	if !almostEqual(result.AveTurnaround, wantTurn) {
+		t.Errorf("AveTurnaround = %v, want %v", result.AveTurnaround, wantTurn)
+	}
+	if !almostEqual(result.AveResponse, wantResponse) {
+		t.Errorf("AveResponse = %v, want %v", result.AveResponse, wantResponse)
+	}
+
+	for _, row := range result.Rows {
+		if row.PID == 2 && row.Completion != 10 {
+			t.Errorf("P2 completion = %d, want 10 (expected IO block 4..7, boost at t=8)", row.Completion)
+		}
+		if row.PID == 1 && row.Completion != 12 {
+			t.Errorf("P1 completion = %d, want 12 (expected boost back to queue 0 at t=8)", row.Completion)
+		}
+	}
+
+	var sawIOSlice bool
+	for _, slice := range result.Gantt {
+		if slice.PID != 2 || slice.Kind != SliceIO {
+			continue
+		}
+		sawIOSlice = true
+		// The slice must span exactly P2's 3-tick IO burst (4..7), not
+		// whatever tick another job happened to be on the CPU when P2's IO
+		// finished.
+		if slice.Start != 4 || slice.Stop != 7 {
+			t.Errorf("P2 IO slice = %d..%d, want 4..7", slice.Start, slice.Stop)
+		}
+	}
+	if !sawIOSlice {
+		t.Fatal("expected a SliceIO entry for P2, found none")
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	const epsilon = 1e-9
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff < epsilon
+}
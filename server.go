@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Cookiefied/ProcessScheduler/scheduler"
+)
+
+// runServe starts an HTTP server at addr that streams algorithm's execution
+// over processes as a live-updating Gantt chart in the browser, via
+// server-sent events. It blocks until the server returns an error (e.g. the
+// listener can't be opened).
+func runServe(addr string, algorithm scheduler.Algorithm, processes []scheduler.Process) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", serveIndex)
+	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		serveEvents(w, r, algorithm, processes)
+	})
+
+	log.Printf("serving a live %q Gantt chart on %s", algorithm.Name, addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+// serveEvents runs algorithm over processes on a fresh scheduler.Engine and
+// streams the result as SSE: one "schedule" event carrying the full
+// SchedulerResult, then one "tick" event per scheduler.Event interleaved
+// with a "heartbeat" event every few ticks carrying current queue depth,
+// then "done".
+func serveEvents(w http.ResponseWriter, r *http.Request, algorithm scheduler.Algorithm, processes []scheduler.Process) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// writeMu guards w: the event loop below and the Heartbeat callback
+	// (invoked from Engine's own goroutine) both write SSE frames to it.
+	var writeMu sync.Mutex
+	engine := &scheduler.Engine{
+		TickDuration: 150 * time.Millisecond,
+		// Every 4 ticks, so a consumer sees run-queue depth between Events
+		// instead of just silence on a long CPU burst or IO wait.
+		HeartbeatEvery: 4,
+		Heartbeat: func(tick int64, waiting []int64) {
+			payload, err := json.Marshal(struct {
+				Tick    int64   `json:"tick"`
+				Waiting []int64 `json:"waiting"`
+			}{Tick: tick, Waiting: waiting})
+			if err != nil {
+				return
+			}
+			writeMu.Lock()
+			defer writeMu.Unlock()
+			_, _ = fmt.Fprintf(w, "event: heartbeat\ndata: %s\n\n", payload)
+			flusher.Flush()
+		},
+	}
+	events, result := engine.Run(ctx, algorithm, clonedProcesses(processes))
+
+	if payload, err := json.Marshal(result); err == nil {
+		writeMu.Lock()
+		_, _ = fmt.Fprintf(w, "event: schedule\ndata: %s\n\n", payload)
+		flusher.Flush()
+		writeMu.Unlock()
+	}
+
+	for ev := range events {
+		data, err := json.Marshal(ev)
+		if err != nil {
+			continue
+		}
+		writeMu.Lock()
+		_, _ = fmt.Fprintf(w, "event: tick\ndata: %s\n\n", data)
+		flusher.Flush()
+		writeMu.Unlock()
+	}
+
+	writeMu.Lock()
+	_, _ = fmt.Fprint(w, "event: done\ndata: {}\n\n")
+	flusher.Flush()
+	writeMu.Unlock()
+}
+
+func serveIndex(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(indexHTML))
+}
+
+// indexHTML is a minimal, dependency-free page: it opens an EventSource
+// against /events and appends a colored block to the Gantt chart for every
+// dispatch/io_start tick it receives.
+const indexHTML = `<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Live scheduler Gantt chart</title>
+<style>
+  body { font-family: sans-serif; margin: 2rem; }
+  #gantt { display: flex; align-items: flex-end; flex-wrap: wrap; gap: 1px;
+           min-height: 40px; border-bottom: 2px solid #333; }
+  .slice { height: 36px; min-width: 24px; display: flex; align-items: center;
+           justify-content: center; font-size: 12px; color: white; padding: 0 4px; }
+  .cpu { background: #2b6cb0; }
+  .io { background: #d69e2e; }
+  #log { font-family: monospace; font-size: 13px; white-space: pre-wrap;
+         margin-top: 1rem; max-height: 40vh; overflow-y: auto; }
+</style>
+</head>
+<body>
+<h1>Live scheduler Gantt chart</h1>
+<div id="gantt"></div>
+<div id="log"></div>
+<script>
+  const gantt = document.getElementById('gantt');
+  const log = document.getElementById('log');
+
+  function appendSlice(pid, cls) {
+    const el = document.createElement('div');
+    el.className = 'slice ' + cls;
+    el.textContent = 'P' + pid;
+    gantt.appendChild(el);
+  }
+
+  const source = new EventSource('/events');
+
+  source.addEventListener('schedule', (e) => {
+    const result = JSON.parse(e.data);
+    document.title = result.title;
+    log.textContent += 'Running "' + result.title + '"...\n';
+  });
+
+  source.addEventListener('tick', (e) => {
+    const ev = JSON.parse(e.data);
+    log.textContent += '[t=' + ev.tick + '] ' + ev.kind + ' P' + ev.pid + '\n';
+    if (ev.kind === 'dispatch') {
+      appendSlice(ev.pid, 'cpu');
+    } else if (ev.kind === 'io_start') {
+      appendSlice(ev.pid, 'io');
+    }
+    log.scrollTop = log.scrollHeight;
+  });
+
+  source.addEventListener('heartbeat', (e) => {
+    const hb = JSON.parse(e.data);
+    log.textContent += '[t=' + hb.tick + '] waiting: ' + JSON.stringify(hb.waiting) + '\n';
+    log.scrollTop = log.scrollHeight;
+  });
+
+  source.addEventListener('done', () => {
+    log.textContent += '--- schedule complete ---\n';
+    source.close();
+  });
+</script>
+</body>
+</html>
+`